@@ -16,6 +16,8 @@ export {
   ClearValueType,
   ClearValues,
   initSDK,
+  selfTest,
+  SelfTestResult,
   SepoliaConfig,
   MainnetConfig,
   Auth,
@@ -1,3 +1,4 @@
 export const initSDK = window.relayerSDK.initSDK;
 export const createInstance = window.relayerSDK.createInstance;
 export const SepoliaConfig = window.relayerSDK.SepoliaConfig;
+export const selfTest = window.relayerSDK.selfTest;
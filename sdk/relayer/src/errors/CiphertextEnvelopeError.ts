@@ -0,0 +1,16 @@
+import { RelayerErrorBase } from './RelayerErrorBase';
+import { ensureError } from './utils';
+
+export type CiphertextEnvelopeErrorType = CiphertextEnvelopeError & {
+  name: 'CiphertextEnvelopeError';
+};
+
+export class CiphertextEnvelopeError extends RelayerErrorBase {
+  constructor({ message, cause }: { message: string; cause?: unknown }) {
+    super({
+      message,
+      name: 'CiphertextEnvelopeError',
+      ...(cause ? { cause: ensureError(cause) } : {}),
+    });
+  }
+}
@@ -49,6 +49,27 @@ import type { BytesHex, ZKProof } from './types/primitives';
 
 export { generateKeypair, createEIP712 };
 export { getErrorCauseStatus, getErrorCauseCode } from './relayer/error';
+export {
+  createEncryptedInputCallRequest,
+  toEncryptedInputCallArgs,
+} from './relayer/jsonRpc';
+export type {
+  EncryptedInputResult,
+  EncryptedInputCallArgs,
+  JsonRpcCallMethod,
+  JsonRpcRequest,
+  JsonRpcTransaction,
+  CreateEncryptedInputCallRequestParams,
+} from './relayer/jsonRpc';
+export {
+  sealCiphertext,
+  openCiphertext,
+  CIPHERTEXT_ENVELOPE_VERSION,
+} from './relayer/ciphertextEnvelope';
+export type {
+  SealCiphertextParams,
+  OpenCiphertextParams,
+} from './relayer/ciphertextEnvelope';
 export type { EncryptionBits } from './types/primitives';
 export type {
   EIP712,
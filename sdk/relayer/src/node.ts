@@ -25,6 +25,14 @@ export type {
   ApiKeyHeader,
   FhevmInstanceConfig,
   FhevmInstanceOptions,
+  EncryptedInputResult,
+  EncryptedInputCallArgs,
+  JsonRpcCallMethod,
+  JsonRpcRequest,
+  JsonRpcTransaction,
+  CreateEncryptedInputCallRequestParams,
+  SealCiphertextParams,
+  OpenCiphertextParams,
 } from './index';
 
 export {
@@ -35,6 +43,17 @@ export {
   createEIP712,
   getErrorCauseCode,
   getErrorCauseStatus,
+  createEncryptedInputCallRequest,
+  toEncryptedInputCallArgs,
+  sealCiphertext,
+  openCiphertext,
+  CIPHERTEXT_ENVELOPE_VERSION,
 } from './index';
 
 export { createTfheKeypair, createTfhePublicKey } from './tfhe';
+export { selfTest } from './selfTest';
+export type {
+  SelfTestResult,
+  SelfTestTimings,
+  SelfTestMemory,
+} from './selfTest';
@@ -0,0 +1,122 @@
+import {
+  CIPHERTEXT_ENVELOPE_VERSION,
+  openCiphertext,
+  sealCiphertext,
+} from './ciphertextEnvelope';
+import type { ChecksummedAddress } from '../types/primitives';
+
+// npx jest --colors --passWithNoTests ./src/relayer/ciphertextEnvelope.test.ts
+
+const KEY = new Uint8Array(32).fill(7);
+const OTHER_KEY = new Uint8Array(32).fill(8);
+const SENDER: ChecksummedAddress = '0x8ba1f109551bD432803012645Ac136ddd64DBA72';
+const OTHER_SENDER: ChecksummedAddress =
+  '0xa5e1defb98EFe38EBb2D958CEe052410247F4c80';
+const CIPHERTEXT = new Uint8Array([0, 1, 2, 3, 4, 5, 6, 7, 8, 9]);
+const AAD = new Uint8Array([0xca, 0xfe]);
+
+describe('ciphertextEnvelope', () => {
+  it('seal and open', async () => {
+    const envelope = await sealCiphertext({
+      key: KEY,
+      ciphertext: CIPHERTEXT,
+      sender: SENDER,
+      associatedData: AAD,
+    });
+    expect(envelope[0]).toBe(CIPHERTEXT_ENVELOPE_VERSION);
+    expect(envelope.length).toBe(1 + 12 + 32 + CIPHERTEXT.length + 16);
+
+    const opened = await openCiphertext({
+      key: KEY,
+      envelope,
+      sender: SENDER,
+      associatedData: AAD,
+    });
+    expect(opened).toEqual(CIPHERTEXT);
+  });
+
+  it('uses a fresh nonce per envelope', async () => {
+    const a = await sealCiphertext({
+      key: KEY,
+      ciphertext: CIPHERTEXT,
+      sender: SENDER,
+    });
+    const b = await sealCiphertext({
+      key: KEY,
+      ciphertext: CIPHERTEXT,
+      sender: SENDER,
+    });
+    expect(a).not.toEqual(b);
+  });
+
+  it('rejects a different key', async () => {
+    const envelope = await sealCiphertext({
+      key: KEY,
+      ciphertext: CIPHERTEXT,
+      sender: SENDER,
+    });
+    await expect(
+      openCiphertext({ key: OTHER_KEY, envelope, sender: SENDER }),
+    ).rejects.toThrow('Ciphertext envelope was not sealed with this key.');
+  });
+
+  it('rejects a different sender or associated data', async () => {
+    const envelope = await sealCiphertext({
+      key: KEY,
+      ciphertext: CIPHERTEXT,
+      sender: SENDER,
+      associatedData: AAD,
+    });
+    await expect(
+      openCiphertext({
+        key: KEY,
+        envelope,
+        sender: OTHER_SENDER,
+        associatedData: AAD,
+      }),
+    ).rejects.toThrow('Ciphertext envelope authentication failed');
+    await expect(
+      openCiphertext({ key: KEY, envelope, sender: SENDER }),
+    ).rejects.toThrow('Ciphertext envelope authentication failed');
+  });
+
+  it('rejects tampered data', async () => {
+    const envelope = await sealCiphertext({
+      key: KEY,
+      ciphertext: CIPHERTEXT,
+      sender: SENDER,
+    });
+    envelope[envelope.length - 1] ^= 1;
+    await expect(
+      openCiphertext({ key: KEY, envelope, sender: SENDER }),
+    ).rejects.toThrow('Ciphertext envelope authentication failed');
+  });
+
+  it('rejects malformed envelopes', async () => {
+    await expect(
+      openCiphertext({
+        key: KEY,
+        envelope: new Uint8Array(10),
+        sender: SENDER,
+      }),
+    ).rejects.toThrow('Ciphertext envelope is too short (10 bytes).');
+
+    const envelope = await sealCiphertext({
+      key: KEY,
+      ciphertext: CIPHERTEXT,
+      sender: SENDER,
+    });
+    envelope[0] = 2;
+    await expect(
+      openCiphertext({ key: KEY, envelope, sender: SENDER }),
+    ).rejects.toThrow('Unsupported ciphertext envelope version 2.');
+
+    await expect(
+      sealCiphertext({
+        key: new Uint8Array(16),
+        ciphertext: CIPHERTEXT,
+        sender: SENDER,
+      }),
+    ).rejects.toThrow('Ciphertext envelope key must be 32 bytes, got 16.');
+  });
+});
@@ -0,0 +1,181 @@
+import { CiphertextEnvelopeError } from '../errors/CiphertextEnvelopeError';
+import { checksummedAddressToBytes20 } from '../utils/address';
+import { concatBytes } from '../utils/bytes';
+import type { ChecksummedAddress } from '../types/primitives';
+
+////////////////////////////////////////////////////////////////////////////////
+//
+// AEAD envelope for serialized ciphertexts on untrusted transports.
+//
+// envelope = version (1) || nonce (12) || commitment (32) || AES-256-GCM(ct)
+//
+// Per-envelope encryption and commitment keys are derived from the shared key
+// with HKDF-SHA256 (salt = nonce). The commitment is checked before
+// decryption, which makes the envelope key-committing: it cannot be opened
+// under a key other than the one it was sealed with. The sender address and
+// optional caller data are authenticated as AES-GCM associated data.
+//
+// The sender is not proof of origin: anyone holding the shared key can seal
+// an envelope under any sender address. Use per-sender keys or signatures when
+// the origin must be established.
+//
+////////////////////////////////////////////////////////////////////////////////
+
+export const CIPHERTEXT_ENVELOPE_VERSION = 1;
+
+const KEY_LENGTH = 32;
+const NONCE_LENGTH = 12;
+const COMMITMENT_LENGTH = 32;
+const TAG_LENGTH = 16;
+const HEADER_LENGTH = 1 + NONCE_LENGTH + COMMITMENT_LENGTH;
+
+const INFO_ENCRYPTION = new TextEncoder().encode('luxfhe-envelope-enc');
+const INFO_COMMITMENT = new TextEncoder().encode('luxfhe-envelope-commit');
+
+export type SealCiphertextParams = {
+  /** 32-byte key shared by sender and recipient. */
+  key: Uint8Array;
+  /** Serialized FHE ciphertext. */
+  ciphertext: Uint8Array;
+  sender: ChecksummedAddress;
+  associatedData?: Uint8Array;
+};
+
+export type OpenCiphertextParams = {
+  key: Uint8Array;
+  envelope: Uint8Array;
+  sender: ChecksummedAddress;
+  associatedData?: Uint8Array;
+};
+
+export async function sealCiphertext({
+  key,
+  ciphertext,
+  sender,
+  associatedData,
+}: SealCiphertextParams): Promise<Uint8Array> {
+  const nonce = crypto.getRandomValues(new Uint8Array(NONCE_LENGTH));
+  const { encryptionKey, commitment } = await _deriveKeys(key, nonce);
+  const aad = _associatedData(sender, associatedData);
+
+  const sealed = await crypto.subtle.encrypt(
+    {
+      name: 'AES-GCM',
+      iv: nonce,
+      additionalData: aad,
+      tagLength: TAG_LENGTH * 8,
+    },
+    encryptionKey,
+    ciphertext,
+  );
+
+  return concatBytes(
+    new Uint8Array([CIPHERTEXT_ENVELOPE_VERSION]),
+    nonce,
+    commitment,
+    new Uint8Array(sealed),
+  );
+}
+
+export async function openCiphertext({
+  key,
+  envelope,
+  sender,
+  associatedData,
+}: OpenCiphertextParams): Promise<Uint8Array> {
+  if (envelope.length < HEADER_LENGTH + TAG_LENGTH) {
+    throw new CiphertextEnvelopeError({
+      message: `Ciphertext envelope is too short (${envelope.length} bytes).`,
+    });
+  }
+  if (envelope[0] !== CIPHERTEXT_ENVELOPE_VERSION) {
+    throw new CiphertextEnvelopeError({
+      message: `Unsupported ciphertext envelope version ${envelope[0]}.`,
+    });
+  }
+
+  const nonce = envelope.slice(1, 1 + NONCE_LENGTH);
+  const commitment = envelope.slice(1 + NONCE_LENGTH, HEADER_LENGTH);
+  const sealed = envelope.slice(HEADER_LENGTH);
+
+  const derived = await _deriveKeys(key, nonce);
+  if (!_constantTimeEquals(commitment, derived.commitment)) {
+    throw new CiphertextEnvelopeError({
+      message: 'Ciphertext envelope was not sealed with this key.',
+    });
+  }
+
+  try {
+    const ciphertext = await crypto.subtle.decrypt(
+      {
+        name: 'AES-GCM',
+        iv: nonce,
+        additionalData: _associatedData(sender, associatedData),
+        tagLength: TAG_LENGTH * 8,
+      },
+      derived.encryptionKey,
+      sealed,
+    );
+    return new Uint8Array(ciphertext);
+  } catch (e) {
+    throw new CiphertextEnvelopeError({
+      message:
+        'Ciphertext envelope authentication failed (tampered data, wrong sender or associated data).',
+      cause: e,
+    });
+  }
+}
+
+async function _deriveKeys(
+  key: Uint8Array,
+  nonce: Uint8Array,
+): Promise<{ encryptionKey: CryptoKey; commitment: Uint8Array }> {
+  if (key.length !== KEY_LENGTH) {
+    throw new CiphertextEnvelopeError({
+      message: `Ciphertext envelope key must be ${KEY_LENGTH} bytes, got ${key.length}.`,
+    });
+  }
+
+  const ikm = await crypto.subtle.importKey('raw', key, 'HKDF', false, [
+    'deriveBits',
+  ]);
+  const hkdf = (info: Uint8Array) =>
+    crypto.subtle.deriveBits(
+      { name: 'HKDF', hash: 'SHA-256', salt: nonce, info },
+      ikm,
+      KEY_LENGTH * 8,
+    );
+
+  const encryptionKey = await crypto.subtle.importKey(
+    'raw',
+    await hkdf(INFO_ENCRYPTION),
+    'AES-GCM',
+    false,
+    ['encrypt', 'decrypt'],
+  );
+  const commitment = new Uint8Array(await hkdf(INFO_COMMITMENT));
+
+  return { encryptionKey, commitment };
+}
+
+function _associatedData(
+  sender: ChecksummedAddress,
+  associatedData: Uint8Array | undefined,
+): Uint8Array {
+  return concatBytes(
+    new Uint8Array([CIPHERTEXT_ENVELOPE_VERSION]),
+    checksummedAddressToBytes20(sender),
+    associatedData ?? new Uint8Array(),
+  );
+}
+
+function _constantTimeEquals(a: Uint8Array, b: Uint8Array): boolean {
+  if (a.length !== b.length) {
+    return false;
+  }
+  let diff = 0;
+  for (let i = 0; i < a.length; ++i) {
+    diff |= a[i] ^ b[i];
+  }
+  return diff === 0;
+}
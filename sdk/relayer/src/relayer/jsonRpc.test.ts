@@ -0,0 +1,147 @@
+import fs from 'fs';
+import path from 'path';
+import { Interface } from 'ethers';
+import { FhevmHandle } from '../sdk/FhevmHandle';
+import { hexToBytes } from '../utils/bytes';
+import { AddressError } from '../errors/AddressError';
+import {
+  createEncryptedInputCallRequest,
+  toEncryptedInputCallArgs,
+} from './jsonRpc';
+import type { EncryptedInputCallArgs } from './jsonRpc';
+
+// npx jest --colors --passWithNoTests ./src/relayer/jsonRpc.test.ts
+
+const INPUT_PROOF_ASSET_1 = JSON.parse(
+  fs.readFileSync(
+    path.join(__dirname, '../test/assets/input-proof-payload-1.json'),
+    'utf-8',
+  ),
+);
+
+const CHAIN_ID: number = INPUT_PROOF_ASSET_1.chainId;
+const HANDLE: `0x${string}` = INPUT_PROOF_ASSET_1.handles[0];
+const INPUT_PROOF = '0x0101deadbeef';
+const USER_ADDRESS = '0x8ba1f109551bd432803012645ac136ddd64dba72';
+const CONTRACT_ADDRESS: string = INPUT_PROOF_ASSET_1.contractAddress;
+const TRANSFER = 'function transfer(address to, bytes32 amount, bytes proof)';
+
+const encryptedInput = () => ({
+  handles: [hexToBytes(HANDLE)],
+  inputProof: hexToBytes(INPUT_PROOF),
+});
+
+describe('jsonRpc', () => {
+  it('toEncryptedInputCallArgs', () => {
+    expect(toEncryptedInputCallArgs(encryptedInput(), CHAIN_ID)).toEqual({
+      handles: [HANDLE],
+      inputProof: INPUT_PROOF,
+    });
+  });
+
+  it('toEncryptedInputCallArgs throws on wrong chainId', () => {
+    expect(() => toEncryptedInputCallArgs(encryptedInput(), 1)).toThrow(
+      `FHEVM Handle 0 is bound to chainId ${CHAIN_ID}, expected 1.`,
+    );
+  });
+
+  it('toEncryptedInputCallArgs throws on computed handle', () => {
+    const h = FhevmHandle.fromBytes32Hex(HANDLE);
+    const computed = FhevmHandle.fromComponents({
+      hash21: h.hash21,
+      chainId: h.chainId,
+      fheTypeId: h.fheTypeId,
+      version: h.version,
+      computed: true,
+    });
+    expect(() =>
+      toEncryptedInputCallArgs(
+        { handles: [computed.toBytes32()], inputProof: new Uint8Array() },
+        CHAIN_ID,
+      ),
+    ).toThrow('FHEVM Handle 0 is not an input handle (index: computed).');
+  });
+
+  it('toEncryptedInputCallArgs throws on empty input', () => {
+    expect(() =>
+      toEncryptedInputCallArgs(
+        { handles: [], inputProof: new Uint8Array() },
+        CHAIN_ID,
+      ),
+    ).toThrow(
+      'InvalidPropertyError: encryptedInput.handles unexpected value []',
+    );
+  });
+
+  it('createEncryptedInputCallRequest eth_sendTransaction', () => {
+    const request = createEncryptedInputCallRequest({
+      encryptedInput: encryptedInput(),
+      chainId: CHAIN_ID,
+      from: USER_ADDRESS,
+      to: CONTRACT_ADDRESS,
+      functionSignature: TRANSFER,
+      args: ({ handles, inputProof }) => [USER_ADDRESS, handles[0], inputProof],
+      id: 7,
+      value: BigInt(255),
+    });
+
+    expect(request.jsonrpc).toBe('2.0');
+    expect(request.id).toBe(7);
+    expect(request.method).toBe('eth_sendTransaction');
+    expect(request.params.length).toBe(1);
+
+    const tx = request.params[0];
+    expect(tx.from).toBe('0x8ba1f109551bD432803012645Ac136ddd64DBA72');
+    expect(tx.to).toBe(CONTRACT_ADDRESS);
+    expect(tx.value).toBe('0xff');
+
+    const decoded = new Interface([TRANSFER]).decodeFunctionData(
+      'transfer',
+      tx.data,
+    );
+    expect(decoded[1]).toBe(HANDLE);
+    expect(decoded[2]).toBe(INPUT_PROOF);
+  });
+
+  it('createEncryptedInputCallRequest eth_call', () => {
+    const request = createEncryptedInputCallRequest({
+      encryptedInput: encryptedInput(),
+      chainId: CHAIN_ID,
+      from: USER_ADDRESS,
+      to: CONTRACT_ADDRESS,
+      functionSignature: TRANSFER,
+      args: ({ handles, inputProof }) => [USER_ADDRESS, handles[0], inputProof],
+      method: 'eth_call',
+    });
+
+    expect(request.id).toBe(1);
+    expect(request.params.length).toBe(2);
+    expect(request.params[1]).toBe('latest');
+    expect(request.params[0].value).toBeUndefined();
+  });
+
+  it('createEncryptedInputCallRequest throws on invalid params', () => {
+    const params = {
+      encryptedInput: encryptedInput(),
+      chainId: CHAIN_ID,
+      from: USER_ADDRESS,
+      to: CONTRACT_ADDRESS,
+      functionSignature: TRANSFER,
+      args: ({ handles, inputProof }: EncryptedInputCallArgs) => [
+        USER_ADDRESS,
+        handles[0],
+        inputProof,
+      ],
+    };
+
+    expect(() =>
+      createEncryptedInputCallRequest({ ...params, from: '0x1234' }),
+    ).toThrow(AddressError);
+    expect(() =>
+      createEncryptedInputCallRequest({ ...params, to: 'not an address' }),
+    ).toThrow(AddressError);
+    expect(() =>
+      createEncryptedInputCallRequest({ ...params, value: BigInt(-1) }),
+    ).toThrow('InvalidPropertyError: params.value not a Uint, type is bigint');
+  });
+});
@@ -0,0 +1,179 @@
+import { FunctionFragment, Interface, getAddress } from 'ethers';
+
+import { FhevmHandle } from '../sdk/FhevmHandle';
+import { FhevmHandleError } from '../errors/FhevmHandleError';
+import { InvalidPropertyError } from '../errors/InvalidPropertyError';
+import { assertIsAddress } from '../utils/address';
+import { bytesToHex } from '../utils/bytes';
+import type {
+  Bytes32Hex,
+  BytesHex,
+  ChecksummedAddress,
+} from '../types/primitives';
+
+////////////////////////////////////////////////////////////////////////////////
+// Types
+////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * Result of `RelayerEncryptedInput.encrypt()` or
+ * `FhevmInstance.requestZKProofVerification()`.
+ */
+export type EncryptedInputResult = {
+  handles: Uint8Array[];
+  inputProof: Uint8Array;
+};
+
+/**
+ * ABI-ready form of an encrypted input: one `bytes32` per handle and the
+ * `bytes` input proof shared by all of them.
+ */
+export type EncryptedInputCallArgs = {
+  handles: Bytes32Hex[];
+  inputProof: BytesHex;
+};
+
+export type JsonRpcCallMethod =
+  | 'eth_call'
+  | 'eth_estimateGas'
+  | 'eth_sendTransaction';
+
+export type JsonRpcTransaction = {
+  from: ChecksummedAddress;
+  to: ChecksummedAddress;
+  data: BytesHex;
+  value?: BytesHex;
+};
+
+export type JsonRpcRequest = {
+  jsonrpc: '2.0';
+  id: number | string;
+  method: JsonRpcCallMethod;
+  params: [tx: JsonRpcTransaction, blockTag?: string];
+};
+
+export type CreateEncryptedInputCallRequestParams = {
+  encryptedInput: EncryptedInputResult;
+  chainId: number;
+  from: string;
+  to: string;
+  functionSignature: string;
+  args: (encrypted: EncryptedInputCallArgs) => ReadonlyArray<unknown>;
+  method?: JsonRpcCallMethod;
+  id?: number | string;
+  value?: bigint;
+  blockTag?: string;
+};
+
+////////////////////////////////////////////////////////////////////////////////
+// Encrypted input -> ABI arguments
+////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * Converts an encrypted input into the hex values expected by the contract
+ * ABI. Every handle must be an input (non-computed) handle bound to `chainId`,
+ * otherwise the input verifier on the node rejects the call.
+ */
+export function toEncryptedInputCallArgs(
+  encryptedInput: EncryptedInputResult,
+  chainId: number,
+): EncryptedInputCallArgs {
+  if (encryptedInput.handles.length === 0) {
+    throw new InvalidPropertyError({
+      objName: 'encryptedInput',
+      property: 'handles',
+      type: 'Array',
+      expectedType: 'Array',
+      value: '[]',
+    });
+  }
+
+  const handles = encryptedInput.handles.map((h, index) => {
+    const handle = FhevmHandle.fromBytes32(h);
+    if (handle.computed || handle.index !== index) {
+      throw new FhevmHandleError({
+        message: `FHEVM Handle ${index} is not an input handle (index: ${handle.index ?? 'computed'}).`,
+      });
+    }
+    if (handle.chainId !== chainId) {
+      throw new FhevmHandleError({
+        message: `FHEVM Handle ${index} is bound to chainId ${handle.chainId}, expected ${chainId}.`,
+      });
+    }
+    return handle.toBytes32Hex();
+  });
+
+  return {
+    handles,
+    inputProof: bytesToHex(encryptedInput.inputProof),
+  };
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// JSON-RPC request
+////////////////////////////////////////////////////////////////////////////////
+
+/**
+ * Builds the JSON-RPC request calling `functionSignature` on `to` with an
+ * encrypted input attached. `args` receives the ABI-ready handles and input
+ * proof and returns the full argument list, in declaration order.
+ *
+ * @example
+ * const request = createEncryptedInputCallRequest({
+ *   encryptedInput: await input.add64(100).encrypt(),
+ *   chainId,
+ *   from: userAddress,
+ *   to: tokenAddress,
+ *   functionSignature:
+ *     'function transfer(address to, bytes32 amount, bytes inputProof)',
+ *   args: ({ handles, inputProof }) => [recipient, handles[0], inputProof],
+ * });
+ * await provider.send(request.method, request.params);
+ */
+export function createEncryptedInputCallRequest({
+  encryptedInput,
+  chainId,
+  from,
+  to,
+  functionSignature,
+  args,
+  method = 'eth_sendTransaction',
+  id = 1,
+  value,
+  blockTag = 'latest',
+}: CreateEncryptedInputCallRequestParams): JsonRpcRequest {
+  assertIsAddress(from);
+  assertIsAddress(to);
+  if (value !== undefined && value < BigInt(0)) {
+    throw new InvalidPropertyError({
+      objName: 'params',
+      property: 'value',
+      type: 'bigint',
+      expectedType: 'Uint',
+    });
+  }
+
+  const fragment = FunctionFragment.from(functionSignature);
+  const callArgs = toEncryptedInputCallArgs(encryptedInput, chainId);
+  const data = new Interface([fragment]).encodeFunctionData(
+    fragment,
+    args(callArgs),
+  ) as BytesHex;
+
+  const tx: JsonRpcTransaction = {
+    from: getAddress(from) as ChecksummedAddress,
+    to: getAddress(to) as ChecksummedAddress,
+    data,
+  };
+  if (value !== undefined) {
+    tx.value = `0x${value.toString(16)}`;
+  }
+
+  return {
+    jsonrpc: '2.0',
+    id,
+    method,
+    // eth_sendTransaction takes no block tag
+    params: method === 'eth_sendTransaction' ? [tx] : [tx, blockTag],
+  };
+}
@@ -0,0 +1,38 @@
+import { selfTest } from './selfTest';
+import type { FhevmInstance } from './index';
+
+// npx jest --colors --passWithNoTests ./src/selfTest.test.ts
+
+describe('selfTest', () => {
+  it('round trip', async () => {
+    const result = await selfTest();
+    expect(result.ok).toBe(true);
+    expect(result.timings.keygen).toBeGreaterThanOrEqual(0);
+    expect(result.timings.encrypt).toBeGreaterThanOrEqual(0);
+    expect(result.timings.decrypt).toBeGreaterThanOrEqual(0);
+    expect(result.timings.encryptWithProof).toBeUndefined();
+    expect(result.ciphertextWithZKProofSize).toBeUndefined();
+  }, 60000);
+
+  it('times proven input encryption with an instance', async () => {
+    const added: (number | bigint)[] = [];
+    const input = {
+      add32(value: number | bigint) {
+        added.push(value);
+        return this;
+      },
+      generateZKProof() {
+        return { ciphertextWithZkProof: new Uint8Array(123) };
+      },
+    };
+    const instance = {
+      createEncryptedInput: () => input,
+    } as unknown as FhevmInstance;
+
+    const result = await selfTest({ instance });
+    expect(result.ok).toBe(true);
+    expect(added.length).toBe(1);
+    expect(result.timings.encryptWithProof).toBeGreaterThanOrEqual(0);
+    expect(result.ciphertextWithZKProofSize).toBe(123);
+  }, 60000);
+});
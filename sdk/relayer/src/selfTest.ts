@@ -0,0 +1,115 @@
+import type { FhevmInstance } from './index';
+
+// Arbitrary but valid addresses: the proof is generated locally and never
+// submitted, so they only need to pass the address checks.
+const SELF_TEST_CONTRACT_ADDRESS = '0xa5e1defb98EFe38EBb2D958CEe052410247F4c80';
+const SELF_TEST_USER_ADDRESS = '0x8ba1f109551bd432803012645ac136ddd64dba72';
+
+const SELF_TEST_VALUE = 0x2a2a2a2a;
+
+export type SelfTestTimings = {
+  /** Client key generation (ms). */
+  keygen: number;
+  /** Encryption of one 32-bit value under the client key (ms). */
+  encrypt: number;
+  /** Decryption of that value (ms). */
+  decrypt: number;
+  /**
+   * Local ZK-proven input encryption of one 32-bit value with the instance's
+   * public key and CRS (ms). Only set when an instance is provided.
+   */
+  encryptWithProof?: number;
+};
+
+export type SelfTestMemory = {
+  usedJSHeapSize: number;
+  totalJSHeapSize: number;
+  jsHeapSizeLimit: number;
+};
+
+export type SelfTestResult = {
+  /** True when the decrypted value matches the encrypted one. */
+  ok: boolean;
+  timings: SelfTestTimings;
+  /** Size in bytes of the proven input ciphertext, when measured. */
+  ciphertextWithZKProofSize?: number;
+  /** Logical CPUs reported by the runtime, if available. */
+  hardwareConcurrency?: number;
+  /** JS heap usage after the run. Chromium only. */
+  memory?: SelfTestMemory;
+  userAgent?: string;
+};
+
+/**
+ * Runs a small encrypt/decrypt round trip on the TFHE WASM module and reports
+ * per-step timings and memory, for triaging performance reports.
+ *
+ * The round trip uses a throwaway client key generated locally. When
+ * `instance` is given, `generateZKProof()` on a single 32-bit input is timed
+ * as well; the relayer is not contacted. Homomorphic gates run on the coprocessor, not in the SDK, so
+ * they are not part of the self-test.
+ *
+ * `initSDK()` must have completed before calling this in a browser.
+ */
+export const selfTest = async ({
+  instance,
+}: { instance?: FhevmInstance } = {}): Promise<SelfTestResult> => {
+  const config = TFHE.TfheConfigBuilder.default().build();
+  let clientKey: any;
+  let ct: any;
+  let keygen: number;
+  let ok: boolean;
+  let encrypt: number;
+  let decrypt: number;
+  let t: number;
+  try {
+    t = performance.now();
+    clientKey = TFHE.TfheClientKey.generate(config);
+    keygen = performance.now() - t;
+
+    t = performance.now();
+    ct = TFHE.FheUint32.encrypt_with_client_key(SELF_TEST_VALUE, clientKey);
+    encrypt = performance.now() - t;
+
+    t = performance.now();
+    ok = Number(ct.decrypt(clientKey)) === SELF_TEST_VALUE;
+    decrypt = performance.now() - t;
+  } finally {
+    ct?.free();
+    clientKey?.free();
+    config.free();
+  }
+
+  const result: SelfTestResult = {
+    ok,
+    timings: { keygen, encrypt, decrypt },
+  };
+
+  if (instance !== undefined) {
+    const input = instance
+      .createEncryptedInput(SELF_TEST_CONTRACT_ADDRESS, SELF_TEST_USER_ADDRESS)
+      .add32(SELF_TEST_VALUE);
+    t = performance.now();
+    const zkProof = input.generateZKProof();
+    result.timings.encryptWithProof = performance.now() - t;
+    result.ciphertextWithZKProofSize = zkProof.ciphertextWithZkProof.length;
+  }
+
+  if (typeof navigator !== 'undefined') {
+    result.hardwareConcurrency = navigator.hardwareConcurrency;
+    result.userAgent = navigator.userAgent;
+  }
+
+  // Non-standard, Chromium only
+  const memory = (performance as unknown as { memory?: SelfTestMemory })
+    .memory;
+  if (memory !== undefined) {
+    result.memory = {
+      usedJSHeapSize: memory.usedJSHeapSize,
+      totalJSHeapSize: memory.totalJSHeapSize,
+      jsHeapSizeLimit: memory.jsHeapSizeLimit,
+    };
+  }
+
+  return result;
+};
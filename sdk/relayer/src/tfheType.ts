@@ -20,4 +20,7 @@ export type TFHEType = {
   init_panic_hook: any;
   CompactCiphertextList: any;
   ZkComputeLoad: any;
+  TfheConfigBuilder: any;
+  TfheClientKey: any;
+  FheUint32: any;
 };
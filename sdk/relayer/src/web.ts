@@ -6,6 +6,9 @@ import initTFHE, {
   CompactPkeCrs,
   CompactCiphertextList,
   ZkComputeLoad,
+  TfheConfigBuilder,
+  TfheClientKey,
+  FheUint32,
 } from 'tfhe';
 import {
   default as initTKMS,
@@ -28,6 +31,9 @@ window.TFHE = {
   CompactPkeCrs: CompactPkeCrs as any,
   CompactCiphertextList: CompactCiphertextList as any,
   ZkComputeLoad: ZkComputeLoad as any,
+  TfheConfigBuilder: TfheConfigBuilder as any,
+  TfheClientKey: TfheClientKey as any,
+  FheUint32: FheUint32 as any,
 };
 window.TKMS = {
   default: initTKMS,
@@ -65,6 +71,14 @@ export type {
   ApiKeyHeader,
   FhevmInstanceConfig,
   FhevmInstanceOptions,
+  EncryptedInputResult,
+  EncryptedInputCallArgs,
+  JsonRpcCallMethod,
+  JsonRpcRequest,
+  JsonRpcTransaction,
+  CreateEncryptedInputCallRequestParams,
+  SealCiphertextParams,
+  OpenCiphertextParams,
 } from './index';
 
 export {
@@ -75,6 +89,17 @@ export {
   SepoliaConfig,
   getErrorCauseCode,
   getErrorCauseStatus,
+  createEncryptedInputCallRequest,
+  toEncryptedInputCallArgs,
+  sealCiphertext,
+  openCiphertext,
+  CIPHERTEXT_ENVELOPE_VERSION,
 } from './index';
 
 export { initSDK } from './init';
+export { selfTest } from './selfTest';
+export type {
+  SelfTestResult,
+  SelfTestTimings,
+  SelfTestMemory,
+} from './selfTest';